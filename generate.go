@@ -9,6 +9,7 @@ package main
 import (
 	"crypto/sha256"
 	"encoding/base64"
+	"flag"
 	"fmt"
 	"io/ioutil"
 	"os"
@@ -17,6 +18,7 @@ import (
 	"strings"
 	"text/template"
 
+	"github.com/fsnotify/fsnotify"
 	"github.com/tdewolff/minify/v2"
 	"github.com/tdewolff/minify/v2/css"
 	"github.com/tdewolff/minify/v2/js"
@@ -184,6 +186,17 @@ func generateBundle(bundleFile, pkg, mapName string, srcFiles []string) {
 }
 
 func main() {
+	watch := flag.Bool("watch", false, "rebuild bundles as their source files change instead of exiting after one pass")
+	flag.Parse()
+
+	generateAll()
+
+	if *watch {
+		watchAndRebuild(generateAll)
+	}
+}
+
+func generateAll() {
 	generateJSBundle("ui/static/js.go", map[string][]string{
 		"app": []string{
 			"ui/static/js/dom_helper.js",
@@ -217,3 +230,68 @@ func main() {
 	generateBundle("template/common.go", "template", "templateCommonMap", glob("template/html/common/*.html"))
 	generateBundle("locale/translations.go", "locale", "translations", glob("locale/translations/*.json"))
 }
+
+// watchedDirs lists every directory whose contents feed into a generated
+// bundle. Changing any file under one of them triggers a full rebuild: the
+// bundles are cheap enough to regenerate that tracking which one a given
+// file maps to isn't worth the added bookkeeping.
+var watchedDirs = []string{
+	"ui/static",
+	"template/html",
+	"database/sql",
+	"locale/translations",
+}
+
+// watchAndRebuild watches watchedDirs and calls rebuild whenever a file is
+// created, written, removed or renamed, until the process is interrupted.
+// It lives in generate.go itself, rather than in a separate build-tagged
+// file, so `go run generate.go -watch` keeps working without also having to
+// name extra files on the command line.
+func watchAndRebuild(rebuild func()) {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		panic(err)
+	}
+	defer watcher.Close()
+
+	for _, dir := range watchedDirs {
+		if err := addRecursive(watcher, dir); err != nil {
+			panic(err)
+		}
+	}
+
+	fmt.Println("watching for changes, press Ctrl+C to stop")
+
+	for {
+		select {
+		case event, ok := <-watcher.Events:
+			if !ok {
+				return
+			}
+
+			if event.Op&(fsnotify.Write|fsnotify.Create|fsnotify.Remove|fsnotify.Rename) == 0 {
+				continue
+			}
+
+			fmt.Printf("%s changed, rebuilding bundles\n", event.Name)
+			rebuild()
+		case err, ok := <-watcher.Errors:
+			if !ok {
+				return
+			}
+			fmt.Println("watcher error:", err)
+		}
+	}
+}
+
+func addRecursive(watcher *fsnotify.Watcher, root string) error {
+	return filepath.Walk(root, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			return watcher.Add(path)
+		}
+		return nil
+	})
+}
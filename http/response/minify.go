@@ -0,0 +1,181 @@
+// Copyright 2017 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package response // import "miniflux.app/http/response"
+
+import (
+	"bufio"
+	"bytes"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/tdewolff/minify/v2"
+	"github.com/tdewolff/minify/v2/css"
+	"github.com/tdewolff/minify/v2/html"
+	"github.com/tdewolff/minify/v2/js"
+	"github.com/tdewolff/minify/v2/json"
+	"github.com/tdewolff/minify/v2/xml"
+)
+
+// minifiableTypes maps a response content type to the minifier's own media
+// type, since tdewolff/minify dispatches on that rather than on the full
+// Content-Type header (which may carry a charset parameter).
+var minifiableTypes = map[string]string{
+	"text/html":              "text/html",
+	"application/json":       "application/json",
+	"application/xml":        "text/xml",
+	"text/css":               "text/css",
+	"application/javascript": "text/javascript",
+	"text/javascript":        "text/javascript",
+}
+
+// Formats toggles which content types the Minify middleware processes.
+type Formats struct {
+	HTML bool
+	JSON bool
+	XML  bool
+	CSS  bool
+	JS   bool
+}
+
+func (f Formats) enabled(mediaType string) bool {
+	switch mediaType {
+	case "text/html":
+		return f.HTML
+	case "application/json":
+		return f.JSON
+	case "text/xml":
+		return f.XML
+	case "text/css":
+		return f.CSS
+	case "text/javascript":
+		return f.JS
+	default:
+		return false
+	}
+}
+
+func newMinifier() *minify.M {
+	m := minify.New()
+	m.AddFunc("text/html", html.Minify)
+	m.AddFunc("text/css", css.Minify)
+	m.AddFunc("text/javascript", js.Minify)
+	m.AddFunc("application/json", json.Minify)
+	m.AddFunc("text/xml", xml.Minify)
+	return m
+}
+
+// Minify returns a middleware that minifies outgoing responses according to
+// their Content-Type, rewriting Content-Length accordingly. Requests under
+// /static/ are always passed through untouched: those bundles are already
+// minified at build time (see the Checksums maps in ui/static) and may be
+// binary, so re-buffering and re-minifying them on every request would only
+// waste CPU. Any other response with an unrecognized or disabled content
+// type is streamed straight through as well, preserving Flush/Hijack for
+// handlers like SSE or websocket upgrades.
+func Minify(formats Formats) func(http.Handler) http.Handler {
+	m := newMinifier()
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if isStaticAssetPath(r.URL.Path) {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			mw := &minifyWriter{ResponseWriter: w, formats: formats}
+			next.ServeHTTP(mw, r)
+			mw.finish(m)
+		})
+	}
+}
+
+func isStaticAssetPath(path string) bool {
+	return strings.HasPrefix(path, "/static/")
+}
+
+func contentType(header string) string {
+	if idx := strings.IndexByte(header, ';'); idx != -1 {
+		header = header[:idx]
+	}
+	return strings.TrimSpace(header)
+}
+
+// minifyWriter decides, as soon as the handler calls WriteHeader, whether the
+// response needs minifying. Non-minifiable responses are written straight
+// through to the underlying ResponseWriter so large or streamed payloads
+// never get buffered in memory; only minifiable responses are buffered, so
+// their Content-Length can be recomputed after minification.
+type minifyWriter struct {
+	http.ResponseWriter
+	formats     Formats
+	mediaType   string
+	buf         *bytes.Buffer
+	statusCode  int
+	wroteHeader bool
+}
+
+func (w *minifyWriter) WriteHeader(statusCode int) {
+	if w.wroteHeader {
+		return
+	}
+	w.wroteHeader = true
+	w.statusCode = statusCode
+
+	mediaType, found := minifiableTypes[contentType(w.Header().Get("Content-Type"))]
+	if !found || !w.formats.enabled(mediaType) {
+		w.ResponseWriter.WriteHeader(statusCode)
+		return
+	}
+
+	w.mediaType = mediaType
+	w.buf = &bytes.Buffer{}
+}
+
+func (w *minifyWriter) Write(data []byte) (int, error) {
+	if !w.wroteHeader {
+		w.WriteHeader(http.StatusOK)
+	}
+
+	if w.buf != nil {
+		return w.buf.Write(data)
+	}
+
+	return w.ResponseWriter.Write(data)
+}
+
+func (w *minifyWriter) Flush() {
+	if flusher, ok := w.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+func (w *minifyWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	hijacker, ok := w.ResponseWriter.(http.Hijacker)
+	if !ok {
+		return nil, nil, http.ErrNotSupported
+	}
+	return hijacker.Hijack()
+}
+
+// finish minifies and flushes any buffered response. It is a no-op for
+// responses that were streamed straight through.
+func (w *minifyWriter) finish(m *minify.M) {
+	if w.buf == nil {
+		return
+	}
+
+	minified, err := m.Bytes(w.mediaType, w.buf.Bytes())
+	if err != nil {
+		w.ResponseWriter.WriteHeader(w.statusCode)
+		w.ResponseWriter.Write(w.buf.Bytes())
+		return
+	}
+
+	w.ResponseWriter.Header().Set("Content-Length", strconv.Itoa(len(minified)))
+	w.ResponseWriter.WriteHeader(w.statusCode)
+	w.ResponseWriter.Write(minified)
+}
@@ -0,0 +1,122 @@
+// Copyright 2017 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package response // import "miniflux.app/http/response"
+
+import (
+	"bufio"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+const sampleArticleHTML = `<!DOCTYPE html>
+<html>
+	<head>
+		<title>    Example article    </title>
+	</head>
+	<body>
+		<!-- rendered article view -->
+		<article class="entry">
+			<h1>    Example article    </h1>
+			<p>   This is the article body, with   plenty   of   redundant   whitespace.   </p>
+		</article>
+	</body>
+</html>`
+
+func handlerThatWrites(contentType, body string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", contentType)
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(body))
+	})
+}
+
+func TestMinifyHTMLResponse(t *testing.T) {
+	handler := Minify(Formats{HTML: true})(handlerThatWrites("text/html; charset=utf-8", sampleArticleHTML))
+
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	if recorder.Body.Len() >= len(sampleArticleHTML) {
+		t.Errorf("expected minified body to be smaller than %d bytes, got %d", len(sampleArticleHTML), recorder.Body.Len())
+	}
+
+	if strings.Contains(recorder.Body.String(), "   ") {
+		t.Error("expected redundant whitespace to be removed")
+	}
+}
+
+func TestMinifyPassesThroughDisabledFormat(t *testing.T) {
+	handler := Minify(Formats{HTML: false})(handlerThatWrites("text/html; charset=utf-8", sampleArticleHTML))
+
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	if recorder.Body.String() != sampleArticleHTML {
+		t.Error("expected body to be unchanged when HTML minification is disabled")
+	}
+}
+
+func TestMinifyBypassesStaticAssets(t *testing.T) {
+	handler := Minify(Formats{CSS: true})(handlerThatWrites("text/css; charset=utf-8", "body{color:   red;}"))
+
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/static/css/default.abc123.css", nil))
+
+	if recorder.Body.String() != "body{color:   red;}" {
+		t.Error("expected /static/ responses to bypass minification entirely")
+	}
+}
+
+type flushHijackRecorder struct {
+	*httptest.ResponseRecorder
+	flushed  bool
+	hijacked bool
+}
+
+func (r *flushHijackRecorder) Flush() {
+	r.flushed = true
+}
+
+func (r *flushHijackRecorder) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	r.hijacked = true
+	return nil, nil, nil
+}
+
+func TestMinifyForwardsFlushAndHijack(t *testing.T) {
+	handler := Minify(Formats{HTML: true})(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte("data: hello\n\n"))
+		w.(http.Flusher).Flush()
+		w.(http.Hijacker).Hijack()
+	}))
+
+	recorder := &flushHijackRecorder{ResponseRecorder: httptest.NewRecorder()}
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/stream", nil))
+
+	if !recorder.flushed {
+		t.Error("expected Flush to be forwarded to the underlying ResponseWriter")
+	}
+
+	if !recorder.hijacked {
+		t.Error("expected Hijack to be forwarded to the underlying ResponseWriter")
+	}
+
+	if recorder.Body.String() != "data: hello\n\n" {
+		t.Errorf("expected the event-stream body to pass through unmodified, got %q", recorder.Body.String())
+	}
+}
+
+func BenchmarkMinifyArticleView(b *testing.B) {
+	handler := Minify(Formats{HTML: true})(handlerThatWrites("text/html; charset=utf-8", sampleArticleHTML))
+
+	for i := 0; i < b.N; i++ {
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/", nil))
+	}
+}
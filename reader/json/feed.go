@@ -0,0 +1,65 @@
+// Copyright 2017 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package json // import "miniflux.app/reader/json"
+
+// jsonFeed represents a JSON Feed 1.1 document.
+// https://www.jsonfeed.org/version/1.1/
+type jsonFeed struct {
+	Version     string       `json:"version"`
+	Title       string       `json:"title"`
+	HomePageURL string       `json:"home_page_url"`
+	FeedURL     string       `json:"feed_url"`
+	Description string       `json:"description"`
+	Icon        string       `json:"icon"`
+	Favicon     string       `json:"favicon"`
+	Language    string       `json:"language"`
+	Authors     []jsonAuthor `json:"authors"`
+	Items       []jsonItem   `json:"items"`
+}
+
+type jsonAuthor struct {
+	Name   string `json:"name"`
+	URL    string `json:"url"`
+	Avatar string `json:"avatar"`
+}
+
+type jsonItem struct {
+	ID            string           `json:"id"`
+	URL           string           `json:"url"`
+	ExternalURL   string           `json:"external_url"`
+	Title         string           `json:"title"`
+	ContentHTML   string           `json:"content_html"`
+	ContentText   string           `json:"content_text"`
+	Summary       string           `json:"summary"`
+	Image         string           `json:"image"`
+	BannerImage   string           `json:"banner_image"`
+	DatePublished string           `json:"date_published"`
+	DateModified  string           `json:"date_modified"`
+	Authors       []jsonAuthor     `json:"authors"`
+	Tags          []string         `json:"tags"`
+	Attachments   []jsonAttachment `json:"attachments"`
+}
+
+type jsonAttachment struct {
+	URL           string `json:"url"`
+	MimeType      string `json:"mime_type"`
+	Title         string `json:"title"`
+	SizeInBytes   int64  `json:"size_in_bytes"`
+	DurationInSec int64  `json:"duration_in_seconds"`
+}
+
+func (j *jsonItem) content() string {
+	if j.ContentHTML != "" {
+		return j.ContentHTML
+	}
+	return j.ContentText
+}
+
+func (j *jsonItem) author() string {
+	if len(j.Authors) > 0 {
+		return j.Authors[0].Name
+	}
+	return ""
+}
@@ -0,0 +1,115 @@
+// Copyright 2017 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package json // import "miniflux.app/reader/json"
+
+import (
+	"encoding/json"
+	"io"
+	"time"
+
+	"miniflux.app/crypto"
+	"miniflux.app/errors"
+	"miniflux.app/model"
+	"miniflux.app/reader"
+)
+
+func init() {
+	reader.RegisterParser("application/feed+json", Parse)
+}
+
+// Parse returns a normalized feed struct from a JSON Feed.
+func Parse(data io.Reader) (*model.Feed, *errors.LocalizedError) {
+	feed := new(jsonFeed)
+	decoder := json.NewDecoder(data)
+
+	if err := decoder.Decode(feed); err != nil {
+		return nil, errors.NewLocalizedError("Unable to parse JSON Feed: %q", err)
+	}
+
+	return feed.Transform(), nil
+}
+
+// Transform converts a JSON Feed document into a normalized model.Feed.
+func (j *jsonFeed) Transform() *model.Feed {
+	feed := &model.Feed{
+		Title:   j.Title,
+		FeedURL: j.FeedURL,
+		SiteURL: j.HomePageURL,
+	}
+
+	if feed.Title == "" {
+		feed.Title = feed.SiteURL
+	}
+
+	for _, item := range j.Items {
+		entry := item.Transform()
+		if entry.Author == "" {
+			entry.Author = j.author()
+		}
+		feed.Entries = append(feed.Entries, entry)
+	}
+
+	return feed
+}
+
+func (j *jsonFeed) author() string {
+	if len(j.Authors) > 0 {
+		return j.Authors[0].Name
+	}
+	return ""
+}
+
+// Transform converts a JSON Feed item into a normalized model.Entry.
+func (j *jsonItem) Transform() *model.Entry {
+	entry := &model.Entry{
+		URL:     j.URL,
+		Title:   j.title(),
+		Content: j.content(),
+		Author:  j.author(),
+		Hash:    crypto.Hash(j.hashSource()),
+		Date:    j.date(),
+	}
+
+	for _, attachment := range j.Attachments {
+		entry.Enclosures = append(entry.Enclosures, &model.Enclosure{
+			URL:      attachment.URL,
+			MimeType: attachment.MimeType,
+			Size:     attachment.SizeInBytes,
+		})
+	}
+
+	return entry
+}
+
+func (j *jsonItem) title() string {
+	if j.Title != "" {
+		return j.Title
+	}
+	return j.Summary
+}
+
+func (j *jsonItem) hashSource() string {
+	if j.ID != "" {
+		return j.ID
+	}
+	return j.URL
+}
+
+func (j *jsonItem) date() time.Time {
+	value := j.DatePublished
+	if value == "" {
+		value = j.DateModified
+	}
+
+	if value == "" {
+		return time.Now()
+	}
+
+	if parsed, err := time.Parse(time.RFC3339, value); err == nil {
+		return parsed
+	}
+
+	return time.Now()
+}
@@ -0,0 +1,58 @@
+// Copyright 2017 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package json // import "miniflux.app/reader/json"
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseJSONFeed(t *testing.T) {
+	data := `{
+		"version": "https://jsonfeed.org/version/1.1",
+		"title": "Example Blog",
+		"home_page_url": "https://example.org/",
+		"feed_url": "https://example.org/feed.json",
+		"authors": [{"name": "Alice"}],
+		"items": [
+			{
+				"id": "1",
+				"url": "https://example.org/1",
+				"title": "First post",
+				"content_html": "<p>Hello</p>",
+				"date_published": "2024-01-01T12:00:00Z",
+				"attachments": [
+					{"url": "https://example.org/1.mp3", "mime_type": "audio/mpeg", "size_in_bytes": 1234}
+				]
+			}
+		]
+	}`
+
+	feed, err := Parse(strings.NewReader(data))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if feed.Title != "Example Blog" {
+		t.Errorf("expected title %q, got %q", "Example Blog", feed.Title)
+	}
+
+	if len(feed.Entries) != 1 {
+		t.Fatalf("expected 1 entry, got %d", len(feed.Entries))
+	}
+
+	entry := feed.Entries[0]
+	if entry.Title != "First post" {
+		t.Errorf("expected entry title %q, got %q", "First post", entry.Title)
+	}
+
+	if entry.Author != "Alice" {
+		t.Errorf("expected author %q, got %q", "Alice", entry.Author)
+	}
+
+	if len(entry.Enclosures) != 1 || entry.Enclosures[0].Size != 1234 {
+		t.Errorf("expected one enclosure with size 1234, got %+v", entry.Enclosures)
+	}
+}
@@ -0,0 +1,169 @@
+// Copyright 2017 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+// Package microformats parses IndieWeb h-feed/h-entry documents into
+// normalized feeds, for sites that publish no RSS/Atom/JSON Feed.
+package microformats // import "miniflux.app/reader/microformats"
+
+import (
+	"io"
+	"time"
+
+	"willnorris.com/go/microformats"
+
+	"miniflux.app/crypto"
+	"miniflux.app/errors"
+	"miniflux.app/model"
+	"miniflux.app/reader"
+)
+
+func init() {
+	// h-feed/h-entry documents are served as plain text/html, so there's no
+	// distinct content type to register under: this only ever runs as a
+	// fallback once no RSS/Atom/JSON Feed parser has matched.
+	reader.RegisterFallbackParser(Parse)
+}
+
+// Parse returns a normalized feed struct from an h-feed/h-entry HTML
+// document.
+func Parse(data io.Reader) (*model.Feed, *errors.LocalizedError) {
+	root := microformats.Parse(data, nil)
+
+	hFeed := findItem(root.Items, "h-feed")
+	entries := collectEntries(root.Items)
+
+	if len(entries) == 0 {
+		return nil, errors.NewLocalizedError("Unable to find any h-entry item in this document")
+	}
+
+	feed := &model.Feed{Title: stringProperty(hFeed, "name")}
+	for _, entry := range entries {
+		feed.Entries = append(feed.Entries, transformEntry(entry))
+	}
+
+	return feed, nil
+}
+
+func findItem(items []*microformats.Microformat, itemType string) *microformats.Microformat {
+	for _, item := range items {
+		if hasType(item, itemType) {
+			return item
+		}
+
+		if found := findItem(item.Children, itemType); found != nil {
+			return found
+		}
+	}
+	return nil
+}
+
+func collectEntries(items []*microformats.Microformat) []*microformats.Microformat {
+	var entries []*microformats.Microformat
+
+	for _, item := range items {
+		if hasType(item, "h-entry") {
+			entries = append(entries, item)
+			continue
+		}
+
+		entries = append(entries, collectEntries(item.Children)...)
+	}
+
+	return entries
+}
+
+func hasType(item *microformats.Microformat, itemType string) bool {
+	for _, t := range item.Type {
+		if t == itemType {
+			return true
+		}
+	}
+	return false
+}
+
+func transformEntry(item *microformats.Microformat) *model.Entry {
+	entry := &model.Entry{
+		Title:   stringProperty(item, "name"),
+		URL:     stringProperty(item, "url"),
+		Content: htmlProperty(item, "content"),
+		Author:  authorProperty(item),
+		Date:    dateProperty(item),
+	}
+
+	if entry.Content == "" {
+		entry.Content = stringProperty(item, "summary")
+	}
+
+	entry.Hash = crypto.Hash(entry.URL)
+	return entry
+}
+
+func stringProperty(item *microformats.Microformat, name string) string {
+	if item == nil {
+		return ""
+	}
+
+	values := item.Properties[name]
+	if len(values) == 0 {
+		return ""
+	}
+
+	if value, ok := values[0].(string); ok {
+		return value
+	}
+
+	return ""
+}
+
+func htmlProperty(item *microformats.Microformat, name string) string {
+	if item == nil {
+		return ""
+	}
+
+	values := item.Properties[name]
+	if len(values) == 0 {
+		return ""
+	}
+
+	// e-* properties (e.g. "content") are represented as
+	// map[string]interface{}{"value": "...", "html": "..."}, not
+	// map[string]string.
+	if value, ok := values[0].(map[string]interface{}); ok {
+		if html, ok := value["html"].(string); ok {
+			return html
+		}
+	}
+
+	return ""
+}
+
+func authorProperty(item *microformats.Microformat) string {
+	values := item.Properties["author"]
+	if len(values) == 0 {
+		return ""
+	}
+
+	if author, ok := values[0].(*microformats.Microformat); ok {
+		return stringProperty(author, "name")
+	}
+
+	if name, ok := values[0].(string); ok {
+		return name
+	}
+
+	return ""
+}
+
+func dateProperty(item *microformats.Microformat) time.Time {
+	value := stringProperty(item, "published")
+	if value == "" {
+		return time.Now()
+	}
+
+	if parsed, err := time.Parse(time.RFC3339, value); err == nil {
+		return parsed
+	}
+
+	return time.Now()
+}
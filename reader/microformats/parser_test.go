@@ -0,0 +1,37 @@
+// Copyright 2017 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package microformats // import "miniflux.app/reader/microformats"
+
+import (
+	"testing"
+
+	"willnorris.com/go/microformats"
+)
+
+func TestHTMLPropertyReadsEmbeddedHTMLValue(t *testing.T) {
+	item := &microformats.Microformat{
+		Type: []string{"h-entry"},
+		Properties: map[string][]interface{}{
+			"content": {
+				map[string]interface{}{
+					"value": "Hello",
+					"html":  "<p>Hello</p>",
+				},
+			},
+		},
+	}
+
+	if got := htmlProperty(item, "content"); got != "<p>Hello</p>" {
+		t.Errorf("expected %q, got %q", "<p>Hello</p>", got)
+	}
+}
+
+func TestHTMLPropertyWithoutMatchingProperty(t *testing.T) {
+	item := &microformats.Microformat{Properties: map[string][]interface{}{}}
+
+	if got := htmlProperty(item, "content"); got != "" {
+		t.Errorf("expected an empty string, got %q", got)
+	}
+}
@@ -0,0 +1,25 @@
+// Copyright 2017 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package opml // import "miniflux.app/reader/opml"
+
+// Metadata holds the document-level information carried in an OPML <head>
+// element.
+type Metadata struct {
+	Title        string
+	DateCreated  string
+	DateModified string
+	OwnerName    string
+	OwnerEmail   string
+}
+
+func (h *head) Transform() Metadata {
+	return Metadata{
+		Title:        h.Title,
+		DateCreated:  h.DateCreated,
+		DateModified: h.DateModified,
+		OwnerName:    h.OwnerName,
+		OwnerEmail:   h.OwnerEmail,
+	}
+}
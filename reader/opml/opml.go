@@ -0,0 +1,81 @@
+// Copyright 2017 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package opml // import "miniflux.app/reader/opml"
+
+import "encoding/xml"
+
+type opml struct {
+	XMLName  xml.Name `xml:"opml"`
+	Version  string   `xml:"version,attr"`
+	Header   head     `xml:"head"`
+	Outlines outlines `xml:"body>outline"`
+}
+
+type head struct {
+	Title        string `xml:"title,omitempty"`
+	DateCreated  string `xml:"dateCreated,omitempty"`
+	DateModified string `xml:"dateModified,omitempty"`
+	OwnerName    string `xml:"ownerName,omitempty"`
+	OwnerEmail   string `xml:"ownerEmail,omitempty"`
+}
+
+type outline struct {
+	Title    string   `xml:"title,attr,omitempty"`
+	Text     string   `xml:"text,attr,omitempty"`
+	XMLUrl   string   `xml:"xmlUrl,attr,omitempty"`
+	HTMLUrl  string   `xml:"htmlUrl,attr,omitempty"`
+	Type     string   `xml:"type,attr,omitempty"`
+	Outlines outlines `xml:"outline,omitempty"`
+}
+
+type outlines []outline
+
+// Transform converts the internal representation of the OPML document into
+// a flat list of subscriptions, following nested category outlines down to
+// an arbitrary depth. Miniflux only supports a single level of categories,
+// so a feed found below several folders is tagged with the name of its
+// closest parent folder.
+func (o *opml) Transform() SubcriptionList {
+	var subscriptions SubcriptionList
+
+	for _, outline := range o.Outlines {
+		subscriptions = append(subscriptions, outline.Transform("")...)
+	}
+
+	return subscriptions
+}
+
+func (o *outline) Transform(categoryName string) SubcriptionList {
+	if o.IsFeed() {
+		return SubcriptionList{o.toSubcription(categoryName)}
+	}
+
+	var subscriptions SubcriptionList
+	for _, child := range o.Outlines {
+		subscriptions = append(subscriptions, child.Transform(o.title())...)
+	}
+
+	return subscriptions
+}
+
+func (o *outline) IsFeed() bool {
+	return o.XMLUrl != ""
+}
+
+func (o *outline) title() string {
+	if o.Title != "" {
+		return o.Title
+	}
+	return o.Text
+}
+
+func (o *outline) toSubcription(categoryName string) *Subcription {
+	return &Subcription{
+		Title:        o.title(),
+		SiteURL:      o.HTMLUrl,
+		FeedURL:      o.XMLUrl,
+		CategoryName: categoryName,
+	}
+}
@@ -0,0 +1,155 @@
+// Copyright 2017 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package opml // import "miniflux.app/reader/opml"
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseOPMLWithoutCategories(t *testing.T) {
+	data := `<?xml version="1.0" encoding="UTF-8"?>
+	<opml version="2.0">
+		<body>
+			<outline text="Example" title="Example" type="rss" xmlUrl="http://example.org/feed" htmlUrl="http://example.org/"></outline>
+		</body>
+	</opml>`
+
+	subscriptions, err := Parse(strings.NewReader(data))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(subscriptions) != 1 {
+		t.Fatalf("expected 1 subscription, got %d", len(subscriptions))
+	}
+
+	if subscriptions[0].CategoryName != "" {
+		t.Errorf("expected no category, got %q", subscriptions[0].CategoryName)
+	}
+}
+
+func TestParseOPMLWithNestedCategories(t *testing.T) {
+	// Mimics the kind of hierarchy exported by Feedly/Inoreader/NewsBlur,
+	// where feeds are grouped under category folders.
+	data := `<?xml version="1.0" encoding="UTF-8"?>
+	<opml version="2.0">
+		<head>
+			<title>Subscriptions</title>
+			<dateCreated>Mon, 01 Jan 2024 00:00:00 GMT</dateCreated>
+			<ownerName>Alice</ownerName>
+			<ownerEmail>alice@example.org</ownerEmail>
+		</head>
+		<body>
+			<outline text="News" title="News">
+				<outline text="Example" title="Example" type="rss" xmlUrl="http://example.org/feed" htmlUrl="http://example.org/"></outline>
+			</outline>
+			<outline text="Tech" title="Tech">
+				<outline text="Other" title="Other" type="rss" xmlUrl="http://other.org/feed" htmlUrl="http://other.org/"></outline>
+			</outline>
+		</body>
+	</opml>`
+
+	subscriptions, err := Parse(strings.NewReader(data))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(subscriptions) != 2 {
+		t.Fatalf("expected 2 subscriptions, got %d", len(subscriptions))
+	}
+
+	if subscriptions[0].CategoryName != "News" {
+		t.Errorf("expected category %q, got %q", "News", subscriptions[0].CategoryName)
+	}
+
+	if subscriptions[1].CategoryName != "Tech" {
+		t.Errorf("expected category %q, got %q", "Tech", subscriptions[1].CategoryName)
+	}
+}
+
+func TestRoundtripWithCategories(t *testing.T) {
+	subscriptions := SubcriptionList{
+		{Title: "Example", SiteURL: "http://example.org/", FeedURL: "http://example.org/feed", CategoryName: "News"},
+		{Title: "Other", SiteURL: "http://other.org/", FeedURL: "http://other.org/feed", CategoryName: "Tech"},
+		{Title: "Uncategorized", SiteURL: "http://uncategorized.org/", FeedURL: "http://uncategorized.org/feed"},
+	}
+
+	data, err := Export(subscriptions, Metadata{})
+	if err != nil {
+		t.Fatalf("unable to export subscriptions: %v", err)
+	}
+
+	roundtripped, err := Parse(strings.NewReader(string(data)))
+	if err != nil {
+		t.Fatalf("unable to parse exported OPML: %v", err)
+	}
+
+	if len(roundtripped) != len(subscriptions) {
+		t.Fatalf("expected %d subscriptions, got %d", len(subscriptions), len(roundtripped))
+	}
+
+	for i, subscription := range subscriptions {
+		if roundtripped[i].FeedURL != subscription.FeedURL {
+			t.Errorf("expected feed url %q, got %q", subscription.FeedURL, roundtripped[i].FeedURL)
+		}
+
+		if roundtripped[i].CategoryName != subscription.CategoryName {
+			t.Errorf("expected category %q, got %q", subscription.CategoryName, roundtripped[i].CategoryName)
+		}
+	}
+}
+
+func TestParseMetadata(t *testing.T) {
+	data := `<?xml version="1.0" encoding="UTF-8"?>
+	<opml version="2.0">
+		<head>
+			<title>Subscriptions</title>
+			<dateCreated>Mon, 01 Jan 2024 00:00:00 GMT</dateCreated>
+			<ownerName>Alice</ownerName>
+			<ownerEmail>alice@example.org</ownerEmail>
+		</head>
+		<body></body>
+	</opml>`
+
+	metadata, err := ParseMetadata(strings.NewReader(data))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if metadata.Title != "Subscriptions" {
+		t.Errorf("expected title %q, got %q", "Subscriptions", metadata.Title)
+	}
+
+	if metadata.OwnerName != "Alice" {
+		t.Errorf("expected owner name %q, got %q", "Alice", metadata.OwnerName)
+	}
+
+	if metadata.OwnerEmail != "alice@example.org" {
+		t.Errorf("expected owner email %q, got %q", "alice@example.org", metadata.OwnerEmail)
+	}
+}
+
+func TestExportRoundtripsMetadata(t *testing.T) {
+	metadata := Metadata{
+		Title:      "My Subscriptions",
+		OwnerName:  "Alice",
+		OwnerEmail: "alice@example.org",
+	}
+
+	data, err := Export(nil, metadata)
+	if err != nil {
+		t.Fatalf("unable to export: %v", err)
+	}
+
+	roundtripped, err := ParseMetadata(strings.NewReader(string(data)))
+	if err != nil {
+		t.Fatalf("unable to parse exported metadata: %v", err)
+	}
+
+	if roundtripped != metadata {
+		t.Errorf("expected metadata %+v, got %+v", metadata, roundtripped)
+	}
+}
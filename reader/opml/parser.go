@@ -14,15 +14,35 @@ import (
 
 // Parse reads an OPML file and returns a SubcriptionList.
 func Parse(data io.Reader) (SubcriptionList, *errors.LocalizedError) {
+	feeds, err := decode(data)
+	if err != nil {
+		return nil, err
+	}
+
+	return feeds.Transform(), nil
+}
+
+// ParseMetadata reads the <head> element of an OPML file, so callers that
+// want document-level information (title, owner, dates) alongside the
+// subscription list can read it from a second pass over the data.
+func ParseMetadata(data io.Reader) (Metadata, *errors.LocalizedError) {
+	feeds, err := decode(data)
+	if err != nil {
+		return Metadata{}, err
+	}
+
+	return feeds.Header.Transform(), nil
+}
+
+func decode(data io.Reader) (*opml, *errors.LocalizedError) {
 	feeds := new(opml)
 	decoder := xml.NewDecoder(data)
 	decoder.Entity = xml.HTMLEntity
 	decoder.CharsetReader = encoding.CharsetReader
 
-	err := decoder.Decode(feeds)
-	if err != nil {
+	if err := decoder.Decode(feeds); err != nil {
 		return nil, errors.NewLocalizedError("Unable to parse OPML file: %q", err)
 	}
 
-	return feeds.Transform(), nil
+	return feeds, nil
 }
@@ -0,0 +1,81 @@
+// Copyright 2017 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package opml // import "miniflux.app/reader/opml"
+
+import (
+	"encoding/xml"
+	"io"
+)
+
+// Export serializes a list of subscriptions to OPML 2.0, grouping feeds by
+// category into nested outlines. Categories and uncategorized feeds appear
+// in the document in the order they are first seen in subscriptions, so a
+// document with a single level of category folders, each name used once,
+// keeps its order when round-tripped through Parse/Export. Deeper folders
+// are not kept: each feed is exported under its closest parent folder only.
+func Export(subscriptions SubcriptionList, metadata Metadata) ([]byte, error) {
+	title := metadata.Title
+	if title == "" {
+		title = "Miniflux Subscriptions"
+	}
+
+	document := opml{
+		Version: "2.0",
+		Header: head{
+			Title:        title,
+			DateCreated:  metadata.DateCreated,
+			DateModified: metadata.DateModified,
+			OwnerName:    metadata.OwnerName,
+			OwnerEmail:   metadata.OwnerEmail,
+		},
+	}
+
+	categoryIndex := make(map[string]int)
+
+	for _, subscription := range subscriptions {
+		feedOutline := outline{
+			Text:    subscription.Title,
+			Title:   subscription.Title,
+			XMLUrl:  subscription.FeedURL,
+			HTMLUrl: subscription.SiteURL,
+			Type:    "rss",
+		}
+
+		if subscription.CategoryName == "" {
+			document.Outlines = append(document.Outlines, feedOutline)
+			continue
+		}
+
+		index, found := categoryIndex[subscription.CategoryName]
+		if !found {
+			document.Outlines = append(document.Outlines, outline{
+				Text:  subscription.CategoryName,
+				Title: subscription.CategoryName,
+			})
+			index = len(document.Outlines) - 1
+			categoryIndex[subscription.CategoryName] = index
+		}
+
+		document.Outlines[index].Outlines = append(document.Outlines[index].Outlines, feedOutline)
+	}
+
+	data, err := xml.MarshalIndent(document, "", "    ")
+	if err != nil {
+		return nil, err
+	}
+
+	return append([]byte(xml.Header), data...), nil
+}
+
+// Render writes the OPML 2.0 representation of the given subscriptions to w.
+func Render(w io.Writer, subscriptions SubcriptionList, metadata Metadata) error {
+	data, err := Export(subscriptions, metadata)
+	if err != nil {
+		return err
+	}
+
+	_, err = w.Write(data)
+	return err
+}
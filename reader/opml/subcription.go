@@ -0,0 +1,26 @@
+// Copyright 2017 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package opml // import "miniflux.app/reader/opml"
+
+import "fmt"
+
+// Subcription represents a feed that will be imported or exported.
+type Subcription struct {
+	Title        string
+	SiteURL      string
+	FeedURL      string
+	CategoryName string
+}
+
+func NewSubcription(title, siteURL, feedURL string) *Subcription {
+	return &Subcription{Title: title, SiteURL: siteURL, FeedURL: feedURL}
+}
+
+func (s *Subcription) String() string {
+	return fmt.Sprintf(`Title=%q, SiteURL=%q, FeedURL=%q, CategoryName=%q`, s.Title, s.SiteURL, s.FeedURL, s.CategoryName)
+}
+
+// SubcriptionList represents a list of Subscription.
+type SubcriptionList []*Subcription
@@ -0,0 +1,90 @@
+// Copyright 2017 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package reader // import "miniflux.app/reader"
+
+import (
+	"io"
+	"strings"
+
+	"miniflux.app/errors"
+	"miniflux.app/model"
+)
+
+// ParserFunc parses a feed document and returns a normalized model.Feed.
+type ParserFunc func(io.Reader) (*model.Feed, *errors.LocalizedError)
+
+var parsers = make(map[string]ParserFunc)
+
+// fallbackParsers are tried, in registration order, against text/html
+// responses that don't otherwise match a registered content type. Unlike
+// RegisterParser, these have no content type of their own to dispatch on:
+// the parser itself is the sniff, since it errors out when the document
+// doesn't actually contain what it's looking for (e.g. no h-entry item).
+var fallbackParsers []ParserFunc
+
+// RegisterParser associates a feed parser with a content type, so the
+// fetcher can dispatch to it based on the sniffed response content type
+// instead of hard-coding format detection.
+func RegisterParser(contentType string, fn ParserFunc) {
+	parsers[contentType] = fn
+}
+
+// RegisterFallbackParser registers a parser that Parse tries against
+// text/html responses when no parser is registered for the exact content
+// type. This is how formats with no content type of their own — like
+// Microformats2 h-feed, which is just HTML — opt into being sniffed.
+func RegisterFallbackParser(fn ParserFunc) {
+	fallbackParsers = append(fallbackParsers, fn)
+}
+
+// LookupParser returns the parser registered for the given content type, if
+// any.
+func LookupParser(contentType string) (ParserFunc, bool) {
+	fn, found := parsers[baseContentType(contentType)]
+	return fn, found
+}
+
+// Parse dispatches to the parser registered for contentType and returns a
+// normalized model.Feed. If contentType is text/html and no exact parser
+// matches, it tries each fallback parser in turn.
+func Parse(contentType string, data io.Reader) (*model.Feed, *errors.LocalizedError) {
+	if fn, found := LookupParser(contentType); found {
+		return fn(data)
+	}
+
+	if baseContentType(contentType) == "text/html" {
+		// Each fallback consumes data in turn; callers with more than one
+		// fallback parser registered need to pass a Reader that can be
+		// rewound between attempts (e.g. bytes.NewReader over a buffered
+		// response body).
+		seeker, canSeek := data.(io.Seeker)
+		var start int64
+		if canSeek {
+			var err error
+			if start, err = seeker.Seek(0, io.SeekCurrent); err != nil {
+				canSeek = false
+			}
+		}
+		for i, fn := range fallbackParsers {
+			if i > 0 && canSeek {
+				seeker.Seek(start, io.SeekStart)
+			}
+			if feed, err := fn(data); err == nil {
+				return feed, nil
+			}
+		}
+	}
+
+	return nil, errors.NewLocalizedError("Unsupported feed format: %q", contentType)
+}
+
+// baseContentType strips any parameters (e.g. "; charset=utf-8") from a
+// Content-Type header value.
+func baseContentType(contentType string) string {
+	if idx := strings.IndexByte(contentType, ';'); idx != -1 {
+		contentType = contentType[:idx]
+	}
+	return strings.TrimSpace(contentType)
+}
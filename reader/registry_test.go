@@ -0,0 +1,78 @@
+// Copyright 2017 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package reader // import "miniflux.app/reader"
+
+import (
+	"io"
+	"testing"
+
+	"miniflux.app/errors"
+	"miniflux.app/model"
+)
+
+func TestRegisterAndLookupParser(t *testing.T) {
+	fn := func(io.Reader) (*model.Feed, *errors.LocalizedError) {
+		return &model.Feed{Title: "Test"}, nil
+	}
+
+	RegisterParser("application/test+xml", fn)
+
+	found, ok := LookupParser("application/test+xml")
+	if !ok {
+		t.Fatal("expected a parser to be registered")
+	}
+
+	feed, err := found(nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if feed.Title != "Test" {
+		t.Errorf("expected feed title %q, got %q", "Test", feed.Title)
+	}
+}
+
+func TestParseWithUnknownContentType(t *testing.T) {
+	_, err := Parse("application/does-not-exist", nil)
+	if err == nil {
+		t.Fatal("expected an error for an unregistered content type")
+	}
+}
+
+func TestParseStripsContentTypeParameters(t *testing.T) {
+	RegisterParser("application/test-with-params+xml", func(io.Reader) (*model.Feed, *errors.LocalizedError) {
+		return &model.Feed{Title: "Test"}, nil
+	})
+
+	feed, err := Parse("application/test-with-params+xml; charset=utf-8", nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if feed.Title != "Test" {
+		t.Errorf("expected feed title %q, got %q", "Test", feed.Title)
+	}
+}
+
+func TestParseFallsBackForHTMLContentType(t *testing.T) {
+	called := false
+	RegisterFallbackParser(func(io.Reader) (*model.Feed, *errors.LocalizedError) {
+		called = true
+		return &model.Feed{Title: "Fallback"}, nil
+	})
+
+	feed, err := Parse("text/html; charset=utf-8", nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !called {
+		t.Fatal("expected the fallback parser to be tried for text/html")
+	}
+
+	if feed.Title != "Fallback" {
+		t.Errorf("expected feed title %q, got %q", "Fallback", feed.Title)
+	}
+}
@@ -10,9 +10,14 @@ import (
 
 	"miniflux.app/errors"
 	"miniflux.app/model"
+	"miniflux.app/reader"
 	"miniflux.app/reader/encoding"
 )
 
+func init() {
+	reader.RegisterParser("application/rss+xml", Parse)
+}
+
 // Parse returns a normalized feed struct from a RSS feed.
 func Parse(data io.Reader) (*model.Feed, *errors.LocalizedError) {
 	feed := new(rssFeed)
@@ -0,0 +1,24 @@
+// Copyright 2017 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package template // import "miniflux.app/template"
+
+import (
+	"html/template"
+
+	"miniflux.app/ui/static"
+)
+
+// newFuncMap returns the functions exposed to view templates. Templates
+// reference static bundles by name ("app", "default", "favicon.ico", ...)
+// and get back the bundle's current content-addressed URL, instead of
+// hard-coding a path that would go stale the moment the bundle's checksum
+// changes.
+func newFuncMap() template.FuncMap {
+	return template.FuncMap{
+		"javascript_url": static.JavascriptBundleURL,
+		"stylesheet_url": static.StylesheetBundleURL,
+		"asset_url":      static.BinaryFileURL,
+	}
+}
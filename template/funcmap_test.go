@@ -0,0 +1,40 @@
+// Copyright 2017 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package template // import "miniflux.app/template"
+
+import (
+	"html/template"
+	"strings"
+	"testing"
+
+	"miniflux.app/ui/static"
+)
+
+func TestTemplateRendersContentAddressedBundleURLs(t *testing.T) {
+	static.JavascriptsChecksums = map[string]string{"app": "0123456789abcdef"}
+	static.StylesheetsChecksums = map[string]string{"default": "fedcba9876543210"}
+	static.BinariesChecksums = map[string]string{"favicon.ico": "aaaabbbbccccdddd"}
+
+	tpl := template.Must(template.New("view").Funcs(newFuncMap()).Parse(
+		`<script src="{{ javascript_url "app" }}"></script>` +
+			`<link rel="stylesheet" href="{{ stylesheet_url "default" }}">` +
+			`<link rel="icon" href="{{ asset_url "favicon.ico" }}">`,
+	))
+
+	var out strings.Builder
+	if err := tpl.Execute(&out, nil); err != nil {
+		t.Fatalf("unable to render template: %v", err)
+	}
+
+	for _, want := range []string{
+		`src="/static/js/app.0123456789ab.js"`,
+		`href="/static/css/default.fedcba987654.css"`,
+		`href="/static/favicon.aaaabbbbcccc.ico"`,
+	} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("expected rendered template to contain %q, got %q", want, out.String())
+		}
+	}
+}
@@ -0,0 +1,60 @@
+// Copyright 2017 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package static // import "miniflux.app/ui/static"
+
+import (
+	"io/ioutil"
+	"os"
+	"strings"
+)
+
+// devModeJavascriptFiles and devModeStylesheetFiles mirror the bundle file
+// lists in generate.go. Keep the two
+// in sync: this lets DEV_MODE=1 read bundles straight off disk, so
+// developers don't have to re-run `go generate` after every edit.
+var devModeJavascriptFiles = map[string][]string{
+	"app": {
+		"ui/static/js/dom_helper.js",
+		"ui/static/js/touch_handler.js",
+		"ui/static/js/keyboard_handler.js",
+		"ui/static/js/request_builder.js",
+		"ui/static/js/modal_handler.js",
+		"ui/static/js/app.js",
+		"ui/static/js/bootstrap.js",
+	},
+	"sw": {
+		"ui/static/js/sw.js",
+	},
+}
+
+var devModeStylesheetFiles = map[string][]string{
+	"default":   {"ui/static/css/common.css"},
+	"black":     {"ui/static/css/common.css", "ui/static/css/black.css"},
+	"sansserif": {"ui/static/css/common.css", "ui/static/css/sansserif.css"},
+}
+
+// devModeEnabled reports whether the server should bypass the generated
+// Javascripts/Stylesheets maps and read bundles from disk on every request.
+func devModeEnabled() bool {
+	return os.Getenv("DEV_MODE") == "1"
+}
+
+func devModeBundle(name string, files map[string][]string) (string, bool) {
+	srcFiles, found := files[name]
+	if !found {
+		return "", false
+	}
+
+	var b strings.Builder
+	for _, srcFile := range srcFiles {
+		data, err := ioutil.ReadFile(srcFile)
+		if err != nil {
+			return "", false
+		}
+		b.Write(data)
+	}
+
+	return b.String(), true
+}
@@ -0,0 +1,31 @@
+// Copyright 2017 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package static // import "miniflux.app/ui/static"
+
+import (
+	"net/http/httptest"
+	"os"
+	"testing"
+)
+
+func TestServeJavascriptInDevModeReadsDisk(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(dir+"/dev.js", []byte("console.log('dev');"), 0644); err != nil {
+		t.Fatalf("unable to write fixture: %v", err)
+	}
+
+	os.Setenv("DEV_MODE", "1")
+	defer os.Unsetenv("DEV_MODE")
+
+	Javascripts = map[string]string{"app": "console.log('stale');"}
+	devModeJavascriptFiles = map[string][]string{"app": {dir + "/dev.js"}}
+
+	recorder := httptest.NewRecorder()
+	ServeJavascript(recorder, httptest.NewRequest("GET", "/static/js/app", nil), "app")
+
+	if recorder.Body.String() != "console.log('dev');" {
+		t.Errorf("expected DEV_MODE to read from disk, got %q", recorder.Body.String())
+	}
+}
@@ -0,0 +1,102 @@
+// Copyright 2017 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package static // import "miniflux.app/ui/static"
+
+import (
+	"encoding/base64"
+	"net/http"
+	"regexp"
+)
+
+// contentAddressedName matches "<name>.<checksum>.<ext>", the form produced
+// by JavascriptBundleURL, StylesheetBundleURL and BinaryFileURL. The
+// checksum group is ignored when looking up the bundle: it only needs to be
+// present for the immutable cache headers to be correct, a stale or forged
+// checksum in the URL still resolves to the current content.
+var contentAddressedName = regexp.MustCompile(`^(.+)\.[0-9a-f]{12}(\.[^.]+)?$`)
+
+// ServeJavascript writes the named JS bundle, accepting either its plain
+// name ("app") or its content-addressed form ("app.<checksum>"). In
+// DEV_MODE it rereads the bundle's source files from disk on every request
+// instead of using the generated Javascripts map.
+func ServeJavascript(w http.ResponseWriter, r *http.Request, requestedName string) {
+	serveBundle(w, r, requestedName, Javascripts, devModeJavascriptFiles, "application/javascript; charset=utf-8")
+}
+
+// ServeStylesheet writes the named CSS bundle, with the same DEV_MODE
+// behavior as ServeJavascript.
+func ServeStylesheet(w http.ResponseWriter, r *http.Request, requestedName string) {
+	serveBundle(w, r, requestedName, Stylesheets, devModeStylesheetFiles, "text/css; charset=utf-8")
+}
+
+// ServeBinaryFile writes a static binary file (image, font, favicon, ...)
+// stored base64-encoded in the generated Binaries map.
+func ServeBinaryFile(w http.ResponseWriter, r *http.Request, requestedName string) {
+	name := stripChecksumKeepExtension(requestedName)
+
+	encoded, found := Binaries[name]
+	if !found {
+		http.NotFound(w, r)
+		return
+	}
+
+	data, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	if isContentAddressed(requestedName) {
+		w.Header().Set("Cache-Control", "public, max-age=31536000, immutable")
+	}
+
+	w.Write(data)
+}
+
+func serveBundle(w http.ResponseWriter, r *http.Request, requestedName string, bundles map[string]string, devFiles map[string][]string, contentType string) {
+	name := stripChecksumAndExtension(requestedName)
+
+	content, found := bundles[name]
+	if devModeEnabled() {
+		content, found = devModeBundle(name, devFiles)
+	}
+	if !found {
+		http.NotFound(w, r)
+		return
+	}
+
+	if !devModeEnabled() && isContentAddressed(requestedName) {
+		w.Header().Set("Cache-Control", "public, max-age=31536000, immutable")
+	}
+
+	w.Header().Set("Content-Type", contentType)
+	w.Write([]byte(content))
+}
+
+func isContentAddressed(requestedName string) bool {
+	return contentAddressedName.MatchString(requestedName)
+}
+
+// stripChecksumAndExtension returns the bundle name as used as a key in the
+// generated Javascripts/Stylesheets maps, e.g. "app.<checksum>.js" -> "app".
+func stripChecksumAndExtension(requestedName string) string {
+	matches := contentAddressedName.FindStringSubmatch(requestedName)
+	if matches == nil {
+		return requestedName
+	}
+
+	return matches[1]
+}
+
+// stripChecksumKeepExtension returns the file name as used as a key in the
+// generated Binaries map, e.g. "favicon.<checksum>.ico" -> "favicon.ico".
+func stripChecksumKeepExtension(requestedName string) string {
+	matches := contentAddressedName.FindStringSubmatch(requestedName)
+	if matches == nil {
+		return requestedName
+	}
+
+	return matches[1] + matches[2]
+}
@@ -0,0 +1,61 @@
+// Copyright 2017 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package static // import "miniflux.app/ui/static"
+
+import (
+	"net/http/httptest"
+	"testing"
+)
+
+func TestServeJavascriptByPlainName(t *testing.T) {
+	Javascripts = map[string]string{"app": "console.log(1);"}
+
+	recorder := httptest.NewRecorder()
+	ServeJavascript(recorder, httptest.NewRequest("GET", "/static/js/app", nil), "app")
+
+	if recorder.Body.String() != "console.log(1);" {
+		t.Errorf("unexpected body: %q", recorder.Body.String())
+	}
+
+	if recorder.Header().Get("Cache-Control") != "" {
+		t.Error("plain bundle requests should not get an immutable cache header")
+	}
+}
+
+func TestServeJavascriptByContentAddressedName(t *testing.T) {
+	Javascripts = map[string]string{"app": "console.log(1);"}
+	JavascriptsChecksums = map[string]string{"app": "0123456789abcdef"}
+
+	recorder := httptest.NewRecorder()
+	requestedName := "app." + JavascriptsChecksums["app"][:checksumLength] + ".js"
+	ServeJavascript(recorder, httptest.NewRequest("GET", "/static/js/"+requestedName, nil), requestedName)
+
+	if recorder.Body.String() != "console.log(1);" {
+		t.Errorf("unexpected body: %q", recorder.Body.String())
+	}
+
+	if recorder.Header().Get("Cache-Control") != "public, max-age=31536000, immutable" {
+		t.Errorf("expected an immutable cache header, got %q", recorder.Header().Get("Cache-Control"))
+	}
+}
+
+func TestJavascriptBundleURL(t *testing.T) {
+	JavascriptsChecksums = map[string]string{"app": "0123456789abcdef"}
+
+	url := JavascriptBundleURL("app")
+	expected := "/static/js/app.0123456789ab.js"
+	if url != expected {
+		t.Errorf("expected %q, got %q", expected, url)
+	}
+}
+
+func TestBinaryFileURLWithoutChecksum(t *testing.T) {
+	BinariesChecksums = map[string]string{}
+
+	url := BinaryFileURL("favicon.ico")
+	if url != "/static/favicon.ico" {
+		t.Errorf("expected fallback URL, got %q", url)
+	}
+}
@@ -0,0 +1,57 @@
+// Copyright 2017 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package static // import "miniflux.app/ui/static"
+
+import (
+	"fmt"
+	"strings"
+)
+
+// checksumLength is the number of hex characters from a bundle's SHA-256
+// checksum kept in its content-addressed URL. Collisions at this length are
+// not a concern: the handlers look bundles up by name alone and ignore the
+// checksum part of the URL.
+const checksumLength = 12
+
+// JavascriptBundleURL returns the content-addressed URL of a JS bundle, e.g.
+// "/static/js/app.<checksum>.js". It falls back to the plain bundle name if
+// no checksum is known for name. DEV_MODE does not change the URL.
+func JavascriptBundleURL(name string) string {
+	return bundleURL("js", name, "js", JavascriptsChecksums)
+}
+
+// StylesheetBundleURL returns the content-addressed URL of a CSS bundle, e.g.
+// "/static/css/default.<checksum>.css".
+func StylesheetBundleURL(name string) string {
+	return bundleURL("css", name, "css", StylesheetsChecksums)
+}
+
+// BinaryFileURL returns the content-addressed URL of a static binary file
+// such as an image or favicon, e.g. "/static/favicon.<checksum>.ico".
+func BinaryFileURL(name string) string {
+	checksum, found := BinariesChecksums[name]
+	if !found {
+		return "/static/" + name
+	}
+
+	base := strings.TrimSuffix(name, extensionOf(name))
+	return fmt.Sprintf("/static/%s.%s%s", base, checksum[:checksumLength], extensionOf(name))
+}
+
+func bundleURL(kind, name, extension string, checksums map[string]string) string {
+	checksum, found := checksums[name]
+	if !found {
+		return fmt.Sprintf("/static/%s/%s.%s", kind, name, extension)
+	}
+
+	return fmt.Sprintf("/static/%s/%s.%s.%s", kind, name, checksum[:checksumLength], extension)
+}
+
+func extensionOf(name string) string {
+	if idx := strings.LastIndexByte(name, '.'); idx != -1 {
+		return name[idx:]
+	}
+	return ""
+}